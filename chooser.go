@@ -0,0 +1,124 @@
+package walkeralias
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// Integer is a constraint permitting any signed integer weight type.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Float is a constraint permitting any floating point weight type.
+type Float interface {
+	~float32 | ~float64
+}
+
+var (
+	// ErrNoChoices is returned by NewChooser when called with no choices.
+	ErrNoChoices = errors.New("walkeralias: no choices provided")
+	// ErrNegativeWeight is returned by NewChooser when any choice has a negative weight.
+	ErrNegativeWeight = errors.New("walkeralias: choice has a negative weight")
+	// ErrZeroTotalWeight is returned by NewChooser when all choices' weights sum to zero.
+	ErrZeroTotalWeight = errors.New("walkeralias: choices sum to a zero weight")
+)
+
+// Choice pairs an arbitrary item with its sampling weight.
+type Choice[T any, W Integer | Float] struct {
+	Item   T
+	Weight W
+}
+
+// chooserBucket mirrors bucket, but holds generic items instead of int keys.
+type chooserBucket[T any] struct {
+	threshold float64 // threshold point
+	item1     T       // item below or equal to threshold
+	item2     T       // item above threshold
+}
+
+// Chooser is a generic counterpart to walkerAlias: it samples arbitrary
+// items of type T directly, instead of requiring callers to route through an
+// int key and a separate lookup slice, while reusing the same O(1) alias
+// method internals.
+type Chooser[T any, W Integer | Float] struct {
+	buckets []*chooserBucket[T]
+	r       *rand.Rand
+}
+
+// NewChooser builds a Chooser from the given choices, seeding its own
+// *rand.Rand from the current time. Use NewChooserWithRand for a
+// deterministic seed or a caller-supplied Source.
+// Returns an error if choices is empty, any weight is negative, or the
+// weights sum to zero, since none of those can be turned into a valid
+// probability table.
+func NewChooser[T any, W Integer | Float](choices ...Choice[T, W]) (*Chooser[T, W], error) {
+	return NewChooserWithRand(rand.New(rand.NewSource(time.Now().UnixNano())), choices...)
+}
+
+// NewChooserWithRand is identical to NewChooser, but takes a caller-supplied
+// *rand.Rand instead of seeding its own from the current time, mirroring
+// NewWalkerAliasWithRand's per-instance seeding contract.
+// Chooser involves an O(n) preprocessing step to generate a probability
+// table; subsequent Pick calls are all O(1).
+func NewChooserWithRand[T any, W Integer | Float](r *rand.Rand, choices ...Choice[T, W]) (*Chooser[T, W], error) {
+	if len(choices) == 0 {
+		return nil, ErrNoChoices
+	}
+
+	n := len(choices)
+	var sumWeights float64
+	for _, c := range choices {
+		w := float64(c.Weight)
+		if w < 0 {
+			return nil, ErrNegativeWeight
+		}
+		sumWeights += w
+	}
+	if sumWeights == 0 {
+		return nil, ErrZeroTotalWeight
+	}
+
+	buckets := make([]*chooserBucket[T], 0, n)
+	for _, c := range choices {
+		prob := float64(c.Weight) * float64(n) / sumWeights
+		buckets = append(buckets, &chooserBucket[T]{threshold: prob, item1: c.Item})
+	}
+
+	underfull := make([]int, 0)
+	overfull := make([]int, 0)
+	for i, b := range buckets {
+		if b.threshold < 1 {
+			underfull = append(underfull, i)
+		} else if b.threshold > 1 {
+			overfull = append(overfull, i)
+		}
+	}
+
+	for len(underfull) > 0 && len(overfull) > 0 {
+		u, o := underfull[len(underfull)-1], overfull[len(overfull)-1]
+		underfull = underfull[:len(underfull)-1]
+		under, over := buckets[u], buckets[o]
+		under.item2 = over.item1
+		over.threshold -= 1 - under.threshold
+		if over.threshold < 1 {
+			underfull = append(underfull, o)
+			overfull = overfull[:len(overfull)-1]
+		}
+	}
+
+	return &Chooser[T, W]{buckets: buckets, r: r}, nil
+}
+
+// Pick returns a random item following the weights supplied to NewChooser.
+func (c *Chooser[T, W]) Pick() T {
+	bucketIdx := c.r.Intn(len(c.buckets))
+	b := c.buckets[bucketIdx]
+	prob := c.r.Float64()
+	if prob > b.threshold {
+		return b.item2
+	}
+
+	return b.item1
+}
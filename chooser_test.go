@@ -0,0 +1,72 @@
+package walkeralias
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestChooser_Pick(t *testing.T) {
+	choices := []Choice[string, float64]{
+		{Item: "first", Weight: 3.5},
+		{Item: "second", Weight: 6.5},
+		{Item: "third", Weight: 10},
+	}
+
+	c, err := NewChooserWithRand(rand.New(rand.NewSource(1)), choices...)
+	if err != nil {
+		t.Fatalf("NewChooserWithRand returned unexpected error: %v", err)
+	}
+
+	const iterations = 1000000
+	actual := make(map[string]float64)
+	for i := 0; i < iterations; i++ {
+		actual[c.Pick()] += float64(1) / float64(iterations)
+	}
+
+	var sumWeights float64
+	for _, choice := range choices {
+		sumWeights += choice.Weight
+	}
+
+	const allowedThreshold = 0.003
+	for _, choice := range choices {
+		expected := choice.Weight / sumWeights
+		if math.Abs(actual[choice.Item]-expected) > allowedThreshold {
+			t.Errorf("actual probability (%.5f) for %q differed from expected (%.5f) by more than %.5f",
+				actual[choice.Item], choice.Item, expected, allowedThreshold)
+		}
+	}
+}
+
+func TestNewChooser_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		choices []Choice[int, float64]
+		wantErr error
+	}{
+		{
+			name:    "no choices",
+			choices: nil,
+			wantErr: ErrNoChoices,
+		},
+		{
+			name:    "negative weight",
+			choices: []Choice[int, float64]{{Item: 0, Weight: -1}},
+			wantErr: ErrNegativeWeight,
+		},
+		{
+			name:    "zero total weight",
+			choices: []Choice[int, float64]{{Item: 0, Weight: 0}, {Item: 1, Weight: 0}},
+			wantErr: ErrZeroTotalWeight,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewChooser(tt.choices...); err != tt.wantErr {
+				t.Errorf("NewChooser() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package walkeralias
+
+import (
+	"math/rand/v2"
+	"sync"
+)
+
+// ConcurrentWalkerAlias wraps an immutable alias table with a sync.Pool of
+// per-goroutine *rand.Rand instances, so Random scales linearly across cores
+// instead of contending on a single shared Source. It is only safe to wrap a
+// table that will never be mutated again - pass in the output of
+// NewWalkerAlias, NewWalkerAliasCrypto, or a walkerAlias right after Freeze,
+// not one still being Updated/Added/Removed from.
+type ConcurrentWalkerAlias struct {
+	buckets []*bucket
+	pool    sync.Pool
+}
+
+// NewConcurrentWalkerAlias builds a ConcurrentWalkerAlias sharing w's alias
+// table. newRand is invoked once per pooled *rand.Rand (e.g. seeding from a
+// per-call counter or from crypto/rand), so goroutines never share a Source.
+func NewConcurrentWalkerAlias(w *walkerAlias, newRand func() *rand.Rand) *ConcurrentWalkerAlias {
+	return &ConcurrentWalkerAlias{
+		buckets: w.buckets,
+		pool: sync.Pool{
+			New: func() interface{} {
+				return newRand()
+			},
+		},
+	}
+}
+
+// Random returns a random key following the wrapped table's probabilities.
+// It borrows a *rand.Rand from the pool for the duration of the call, so
+// concurrent callers never block on each other.
+func (c *ConcurrentWalkerAlias) Random() int {
+	r := c.pool.Get().(*rand.Rand)
+	defer c.pool.Put(r)
+
+	bucketIdx := r.Uint64N(uint64(len(c.buckets)))
+	b := c.buckets[bucketIdx]
+	if r.Uint64() > b.threshold {
+		return b.key2
+	}
+
+	return b.key1
+}
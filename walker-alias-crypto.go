@@ -0,0 +1,39 @@
+package walkeralias
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	mathrand "math/rand/v2"
+)
+
+// CSPRNGSource is the subset of math/rand/v2's Source interface a
+// cryptographically secure randomness source needs to implement. Callers
+// with their own CSPRNG can implement this and pass it through rand.New to
+// NewWalkerAliasWithRand directly, bypassing NewWalkerAliasCrypto entirely.
+type CSPRNGSource interface {
+	Uint64() uint64
+}
+
+// cryptoSource adapts crypto/rand.Reader to the math/rand/v2 Source interface.
+type cryptoSource struct{}
+
+// Uint64 reads 8 bytes from crypto/rand.Reader and returns them as a uint64.
+// It panics if crypto/rand.Reader fails, since that indicates the OS's
+// entropy source is unavailable and there is no safe fallback for a
+// CSPRNG-backed sampler.
+func (cryptoSource) Uint64() uint64 {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		panic("walkeralias: crypto/rand unavailable: " + err.Error())
+	}
+
+	return binary.BigEndian.Uint64(buf[:])
+}
+
+// NewWalkerAliasCrypto is identical to NewWalkerAlias, but draws every
+// sample from crypto/rand instead of a seeded PRNG, for callers that can't
+// accept a predictable Source. Each Random call costs two CSPRNG uint64
+// reads: one to pick a bucket, one for the in-bucket coin flip.
+func NewWalkerAliasCrypto(probabilityMap map[int]float64) *walkerAlias {
+	return NewWalkerAliasWithRand(probabilityMap, mathrand.New(cryptoSource{}))
+}
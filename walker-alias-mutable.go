@@ -0,0 +1,190 @@
+package walkeralias
+
+import "sort"
+
+// fenwickTree is a binary-indexed tree over cumulative float64 weights,
+// supporting O(log n) point updates and O(log n) prefix-sum queries. It
+// backs walkerAlias's mutable weight-update path (Update, Add, Remove); the
+// alias table itself stays untouched until Freeze rebuilds it.
+type fenwickTree struct {
+	tree []float64 // 1-indexed internally, tree[0] is unused
+}
+
+// newFenwickTree builds a fenwickTree with room for capacity elements,
+// seeded with the given initial weights, where weights[i] is the weight of
+// the item at position i. Positions in [len(weights), capacity) start at
+// zero weight but are already part of the tree's structure, so a later
+// add() for one of them is a normal O(log n) point update instead of
+// needing a rebuild.
+func newFenwickTree(weights []float64, capacity int) *fenwickTree {
+	if capacity < len(weights) {
+		capacity = len(weights)
+	}
+
+	f := &fenwickTree{tree: make([]float64, capacity+1)}
+	for i, w := range weights {
+		f.add(i, w)
+	}
+
+	return f
+}
+
+// capacity returns the number of positions the tree has room for without a
+// rebuild.
+func (f *fenwickTree) capacity() int {
+	return len(f.tree) - 1
+}
+
+// add adjusts the weight at pos by delta.
+func (f *fenwickTree) add(pos int, delta float64) {
+	for i := pos + 1; i < len(f.tree); i += i & (-i) {
+		f.tree[i] += delta
+	}
+}
+
+// prefixSum returns the sum of weights in [0, pos].
+func (f *fenwickTree) prefixSum(pos int) float64 {
+	var sum float64
+	for i := pos + 1; i > 0; i -= i & (-i) {
+		sum += f.tree[i]
+	}
+
+	return sum
+}
+
+// total returns the sum of every weight currently in the tree.
+func (f *fenwickTree) total() float64 {
+	return f.prefixSum(len(f.tree) - 2)
+}
+
+// ensureMutable lazily builds the Fenwick-tree backing needed by Update, Add
+// and Remove, seeding it from w.weights. It is a no-op once the tree already
+// exists.
+func (w *walkerAlias) ensureMutable() {
+	if w.fenwick != nil {
+		return
+	}
+	if w.weights == nil {
+		w.weights = make(map[int]float64)
+	}
+
+	w.keys = make([]int, 0, len(w.weights))
+	w.keyPos = make(map[int]int, len(w.weights))
+	values := make([]float64, 0, len(w.weights))
+	for k, v := range w.weights {
+		w.keyPos[k] = len(w.keys)
+		w.keys = append(w.keys, k)
+		values = append(values, v)
+	}
+	w.fenwick = newFenwickTree(values, len(values))
+}
+
+// Update changes the weight of an existing key to newWeight, switching w
+// into Fenwick-tree sampling mode (O(log n) updates and samples) until
+// Freeze is next called. If key is not already tracked, Update behaves like
+// Add.
+func (w *walkerAlias) Update(key int, newWeight float64) {
+	w.ensureMutable()
+
+	pos, ok := w.keyPos[key]
+	if !ok {
+		w.addLocked(key, newWeight)
+		return
+	}
+
+	w.fenwick.add(pos, newWeight-w.weights[key])
+	w.weights[key] = newWeight
+}
+
+// Add inserts a new key with the given weight, switching w into Fenwick-tree
+// sampling mode (see Update). If key is already tracked, Add behaves like
+// Update. Add is O(log n) as long as the tree has spare capacity from a
+// previous growth; growFenwickCapacity's doubling keeps that amortized
+// O(log n) even across unboundedly many Add calls, at the cost of an
+// occasional O(n) rebuild - the same trade a growing slice makes.
+func (w *walkerAlias) Add(key int, weight float64) {
+	w.ensureMutable()
+	w.addLocked(key, weight)
+}
+
+func (w *walkerAlias) addLocked(key int, weight float64) {
+	if pos, ok := w.keyPos[key]; ok {
+		w.fenwick.add(pos, weight-w.weights[key])
+		w.weights[key] = weight
+		return
+	}
+
+	if len(w.keys) >= w.fenwick.capacity() {
+		w.growFenwickCapacity()
+	}
+
+	pos := len(w.keys)
+	w.keys = append(w.keys, key)
+	w.keyPos[key] = pos
+	w.weights[key] = weight
+	w.fenwick.add(pos, weight)
+}
+
+// growFenwickCapacity doubles the Fenwick tree's capacity (to at least 1),
+// rebuilding it once from the current weights so the positions it gains are
+// already part of its structure - see newFenwickTree - and can then be
+// filled in by later add() calls in O(log n) instead of another rebuild.
+func (w *walkerAlias) growFenwickCapacity() {
+	newCapacity := w.fenwick.capacity() * 2
+	if newCapacity == 0 {
+		newCapacity = 1
+	}
+
+	values := make([]float64, len(w.keys))
+	for i, k := range w.keys {
+		values[i] = w.weights[k]
+	}
+	w.fenwick = newFenwickTree(values, newCapacity)
+}
+
+// Remove zeroes out key's weight so it is never sampled again, switching w
+// into Fenwick-tree sampling mode (see Update). The Fenwick tree has no
+// notion of deleting a slot, so key's position is kept but its weight is
+// driven to zero; Freeze compacts it away on the next rebuild.
+func (w *walkerAlias) Remove(key int) {
+	w.ensureMutable()
+
+	pos, ok := w.keyPos[key]
+	if !ok {
+		return
+	}
+
+	w.fenwick.add(pos, -w.weights[key])
+	delete(w.weights, key)
+	delete(w.keyPos, key)
+}
+
+// Freeze rebuilds the O(1) alias table from the current weights and drops
+// the Fenwick-tree backing, so subsequent Random calls go back to O(1)
+// sampling. Call Freeze once a burst of Update/Add/Remove calls settles.
+func (w *walkerAlias) Freeze() {
+	if w.fenwick == nil {
+		return
+	}
+
+	rebuilt := NewWalkerAliasWithRand(w.weights, w.r)
+	w.buckets = rebuilt.buckets
+	w.fenwick = nil
+	w.keys = nil
+	w.keyPos = nil
+}
+
+// randomFenwick samples a key in O(log n) via binary search over the
+// Fenwick tree's prefix sums, for use while w is in mutable mode.
+func (w *walkerAlias) randomFenwick() int {
+	total := w.fenwick.total()
+	target := w.r.Float64() * total
+	idx := sort.Search(len(w.keys), func(i int) bool {
+		return w.fenwick.prefixSum(i) >= target
+	})
+	if idx == len(w.keys) {
+		idx--
+	}
+
+	return w.keys[idx]
+}
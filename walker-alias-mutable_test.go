@@ -0,0 +1,76 @@
+package walkeralias
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWalkerAlias_AddBeyondInitialCapacity(t *testing.T) {
+	w := NewWalkerAlias(map[int]float64{0: 1}, 1)
+
+	w.Add(1, 5.0)
+
+	for i := 0; i < 1000; i++ {
+		if key := w.Random(); key != 0 && key != 1 {
+			t.Fatalf("Random() returned untracked key %d", key)
+		}
+	}
+}
+
+func TestWalkerAlias_UpdateAddRemove(t *testing.T) {
+	w := NewWalkerAlias(map[int]float64{
+		0: 3.5,
+		1: 6.5,
+		2: 10,
+	}, 1)
+
+	w.Update(0, 7)
+	w.Add(3, 3)
+	w.Remove(1)
+
+	const iterations = 1000000
+	actual := make(map[int]float64)
+	for i := 0; i < iterations; i++ {
+		actual[w.Random()] += float64(1) / float64(iterations)
+	}
+
+	expected := map[int]float64{0: 7, 2: 10, 3: 3}
+	var sumWeights float64
+	for _, weight := range expected {
+		sumWeights += weight
+	}
+
+	const allowedThreshold = 0.003
+	for key, weight := range expected {
+		expectedProb := weight / sumWeights
+		if math.Abs(actual[key]-expectedProb) > allowedThreshold {
+			t.Errorf("key %d: actual probability (%.5f) differed from expected (%.5f) by more than %.5f",
+				key, actual[key], expectedProb, allowedThreshold)
+		}
+	}
+	if got := actual[1]; got != 0 {
+		t.Errorf("removed key 1 was sampled with probability %.5f, want 0", got)
+	}
+}
+
+func TestWalkerAlias_Freeze(t *testing.T) {
+	w := NewWalkerAlias(map[int]float64{0: 1, 1: 1}, 1)
+
+	w.Update(0, 9)
+	w.Freeze()
+
+	if w.fenwick != nil {
+		t.Fatalf("Freeze() left the Fenwick tree set")
+	}
+
+	const iterations = 1000000
+	actual := make(map[int]float64)
+	for i := 0; i < iterations; i++ {
+		actual[w.Random()] += float64(1) / float64(iterations)
+	}
+
+	const allowedThreshold = 0.003
+	if math.Abs(actual[0]-0.9) > allowedThreshold {
+		t.Errorf("after Freeze, key 0 probability = %.5f, want ~0.9", actual[0])
+	}
+}
@@ -0,0 +1,83 @@
+package walkeralias
+
+import (
+	"container/heap"
+	"math"
+)
+
+// esItem is one candidate in the Efraimidis-Spirakis reservoir: the
+// original key plus its randomized selection key log(u_i)/w_i.
+type esItem struct {
+	key   int
+	esKey float64
+}
+
+// esHeap is a min-heap of esItems ordered by esKey, used to keep the k
+// largest candidates seen so far while streaming through every item once.
+type esHeap []esItem
+
+func (h esHeap) Len() int           { return len(h) }
+func (h esHeap) Less(i, j int) bool { return h[i].esKey < h[j].esKey }
+func (h esHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *esHeap) Push(x interface{}) {
+	*h = append(*h, x.(esItem))
+}
+
+func (h *esHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
+
+// PickN samples up to min(k, n) keys from w without replacement, weighted by
+// their current weights; keys whose weight is zero or negative are never
+// picked, so fewer than min(k, n) keys come back when fewer than that have a
+// positive weight. It uses the Efraimidis-Spirakis A-Res reservoir algorithm:
+// each key i gets a selection key log(u_i)/w_i for u_i ~ Uniform(0,1), and
+// the k keys with the largest selection keys are kept via a min-heap of
+// size k (the log form avoids underflow for very small or very large
+// weights). Random's alias table has no without-replacement equivalent, so
+// PickN builds this transient heap fresh on every call - O(n log k) rather
+// than Random's O(1). The returned keys are in no particular order.
+func (w *walkerAlias) PickN(k int) []int {
+	if k <= 0 || len(w.weights) == 0 {
+		return nil
+	}
+	if k > len(w.weights) {
+		k = len(w.weights)
+	}
+
+	h := make(esHeap, 0, k)
+	for key, weight := range w.weights {
+		if weight <= 0 {
+			continue
+		}
+
+		esKey := math.Log(w.r.Float64()) / weight
+		if h.Len() < k {
+			heap.Push(&h, esItem{key: key, esKey: esKey})
+			continue
+		}
+		if esKey > h[0].esKey {
+			h[0] = esItem{key: key, esKey: esKey}
+			heap.Fix(&h, 0)
+		}
+	}
+
+	result := make([]int, len(h))
+	for i, item := range h {
+		result[i] = item.key
+	}
+
+	return result
+}
+
+// PickNUnique is an alias for PickN: both already sample without
+// replacement, so there is no behavioral difference, only a name matching
+// callers that want the no-duplicates guarantee explicit at the call site.
+func (w *walkerAlias) PickNUnique(k int) []int {
+	return w.PickN(k)
+}
@@ -0,0 +1,52 @@
+package walkeralias
+
+import "testing"
+
+func TestWalkerAlias_PickN(t *testing.T) {
+	pMap := map[int]float64{0: 1, 1: 2, 2: 3, 3: 4}
+	w := NewWalkerAlias(pMap, 1)
+
+	got := w.PickN(2)
+	if len(got) != 2 {
+		t.Fatalf("PickN(2) returned %d keys, want 2", len(got))
+	}
+
+	seen := make(map[int]bool)
+	for _, key := range got {
+		if seen[key] {
+			t.Errorf("PickN(2) returned duplicate key %d", key)
+		}
+		seen[key] = true
+		if _, ok := pMap[key]; !ok {
+			t.Errorf("PickN(2) returned untracked key %d", key)
+		}
+	}
+}
+
+func TestWalkerAlias_PickN_ClampsToPopulationSize(t *testing.T) {
+	w := NewWalkerAlias(map[int]float64{0: 1, 1: 1}, 1)
+
+	if got := w.PickN(10); len(got) != 2 {
+		t.Errorf("PickN(10) on a 2-key population returned %d keys, want 2", len(got))
+	}
+	if got := w.PickN(0); got != nil {
+		t.Errorf("PickN(0) = %v, want nil", got)
+	}
+}
+
+func TestWalkerAlias_PickNUnique(t *testing.T) {
+	w := NewWalkerAlias(map[int]float64{0: 1, 1: 1, 2: 1}, 1)
+
+	got := w.PickNUnique(3)
+	if len(got) != 3 {
+		t.Fatalf("PickNUnique(3) returned %d keys, want 3", len(got))
+	}
+
+	seen := make(map[int]bool)
+	for _, key := range got {
+		if seen[key] {
+			t.Errorf("PickNUnique(3) returned duplicate key %d", key)
+		}
+		seen[key] = true
+	}
+}
@@ -0,0 +1,213 @@
+package walkeralias
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+)
+
+// serializeVersion is the wire-format version byte written by MarshalBinary,
+// bumped whenever the encoding changes so old tables can be rejected rather
+// than silently misread.
+const serializeVersion = 1
+
+// aliasFraction is the fixed-width denominator thresholds are packed
+// against on the wire: a threshold of p is stored as p * aliasFraction,
+// truncated toward zero, giving 53 bits of precision. The lower bits of the
+// in-memory 64-bit threshold are not kept, so a round trip can shift a
+// threshold by about one part in 2^53.
+const aliasFraction = 1 << 53
+
+var (
+	// ErrUnsupportedVersion is returned by UnmarshalBinary when the version
+	// byte does not match any format this build of walkeralias understands.
+	ErrUnsupportedVersion = errors.New("walkeralias: unsupported table version")
+	// ErrTruncatedTable is returned by UnmarshalBinary when the input ends
+	// before a complete table has been read. UnmarshalJSON reports
+	// malformed or cut-off input with encoding/json's own error instead.
+	ErrTruncatedTable = errors.New("walkeralias: truncated table")
+)
+
+// tableEntry is the JSON-friendly view of a single bucket.
+type tableEntry struct {
+	Key1      int     `json:"key1"`
+	Key2      int     `json:"key2"`
+	Threshold float64 `json:"threshold"` // in [0, 1]
+}
+
+// serializedTable is the JSON-friendly view of a whole walkerAlias: the
+// alias buckets plus the original per-key weights, the latter needed to
+// resume Update/Add/Remove/PickN after loading.
+type serializedTable struct {
+	Buckets []tableEntry    `json:"buckets"`
+	Weights map[int]float64 `json:"weights"`
+}
+
+// MarshalBinary encodes the alias table as a compact wire format: a version
+// byte, a varint bucket count, then for each bucket a varint key1, a varint
+// key2, and a fixed-width uint64 threshold (a fraction of aliasFraction),
+// followed by a varint weight count and, for each, a varint key and its
+// float64 weight. w.weights is included because Update/Add/Remove and PickN
+// key off it; w.r is not, so callers need a fresh *rand.Rand to resume
+// sampling - see NewWalkerAliasFromTable.
+func (w *walkerAlias) MarshalBinary() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	buf.WriteByte(serializeVersion)
+
+	var hdr [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(hdr[:], int64(len(w.buckets)))
+	buf.Write(hdr[:n])
+
+	for _, b := range w.buckets {
+		n = binary.PutVarint(hdr[:], int64(b.key1))
+		buf.Write(hdr[:n])
+		n = binary.PutVarint(hdr[:], int64(b.key2))
+		buf.Write(hdr[:n])
+
+		var packed [8]byte
+		binary.BigEndian.PutUint64(packed[:], thresholdToFraction(b.threshold))
+		buf.Write(packed[:])
+	}
+
+	n = binary.PutVarint(hdr[:], int64(len(w.weights)))
+	buf.Write(hdr[:n])
+	for key, weight := range w.weights {
+		n = binary.PutVarint(hdr[:], int64(key))
+		buf.Write(hdr[:n])
+
+		var packed [8]byte
+		binary.BigEndian.PutUint64(packed[:], math.Float64bits(weight))
+		buf.Write(packed[:])
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a table previously produced by MarshalBinary into
+// w's buckets and weights. w.r is left untouched, so callers should set it
+// (e.g. via NewWalkerAliasFromTable) before sampling.
+func (w *walkerAlias) UnmarshalBinary(data []byte) error {
+	if len(data) < 1 {
+		return ErrTruncatedTable
+	}
+	if data[0] != serializeVersion {
+		return ErrUnsupportedVersion
+	}
+	r := bytes.NewReader(data[1:])
+
+	bucketCount, err := binary.ReadVarint(r)
+	if err != nil {
+		return ErrTruncatedTable
+	}
+
+	buckets := make([]*bucket, 0, bucketCount)
+	for i := int64(0); i < bucketCount; i++ {
+		key1, err := binary.ReadVarint(r)
+		if err != nil {
+			return ErrTruncatedTable
+		}
+		key2, err := binary.ReadVarint(r)
+		if err != nil {
+			return ErrTruncatedTable
+		}
+
+		var packed [8]byte
+		if _, err := io.ReadFull(r, packed[:]); err != nil {
+			return ErrTruncatedTable
+		}
+
+		buckets = append(buckets, &bucket{
+			key1:      int(key1),
+			key2:      int(key2),
+			threshold: fractionToThreshold(binary.BigEndian.Uint64(packed[:])),
+		})
+	}
+
+	weightCount, err := binary.ReadVarint(r)
+	if err != nil {
+		return ErrTruncatedTable
+	}
+
+	weights := make(map[int]float64, weightCount)
+	for i := int64(0); i < weightCount; i++ {
+		key, err := binary.ReadVarint(r)
+		if err != nil {
+			return ErrTruncatedTable
+		}
+
+		var packed [8]byte
+		if _, err := io.ReadFull(r, packed[:]); err != nil {
+			return ErrTruncatedTable
+		}
+		weights[int(key)] = math.Float64frombits(binary.BigEndian.Uint64(packed[:]))
+	}
+
+	w.buckets = buckets
+	w.weights = weights
+	return nil
+}
+
+// MarshalJSON encodes the alias table and weights as JSON, for cases where
+// human-readability matters more than the compact binary format.
+func (w *walkerAlias) MarshalJSON() ([]byte, error) {
+	entries := make([]tableEntry, 0, len(w.buckets))
+	for _, b := range w.buckets {
+		entries = append(entries, tableEntry{
+			Key1:      b.key1,
+			Key2:      b.key2,
+			Threshold: float64(b.threshold) / float64(math.MaxUint64),
+		})
+	}
+
+	return json.Marshal(serializedTable{Buckets: entries, Weights: w.weights})
+}
+
+// UnmarshalJSON is the JSON counterpart to UnmarshalBinary; see its docs for
+// the w.r caveat.
+func (w *walkerAlias) UnmarshalJSON(data []byte) error {
+	var table serializedTable
+	if err := json.Unmarshal(data, &table); err != nil {
+		return err
+	}
+
+	buckets := make([]*bucket, 0, len(table.Buckets))
+	for _, e := range table.Buckets {
+		buckets = append(buckets, &bucket{
+			key1:      e.Key1,
+			key2:      e.Key2,
+			threshold: probToThreshold(e.Threshold),
+		})
+	}
+
+	w.buckets = buckets
+	w.weights = table.Weights
+	return nil
+}
+
+// NewWalkerAliasFromTable reconstructs a walkerAlias from a table previously
+// produced by MarshalBinary, skipping the O(n) preprocessing step entirely.
+// r is used directly for subsequent sampling.
+func NewWalkerAliasFromTable(data []byte, r *rand.Rand) (*walkerAlias, error) {
+	w := &walkerAlias{r: r}
+	if err := w.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// thresholdToFraction converts a bucket's internal uint64-of-MaxUint64
+// threshold into the wire format's uint64-of-aliasFraction representation.
+func thresholdToFraction(threshold uint64) uint64 {
+	prob := float64(threshold) / float64(math.MaxUint64)
+	return uint64(prob * aliasFraction)
+}
+
+// fractionToThreshold is the inverse of thresholdToFraction.
+func fractionToThreshold(fraction uint64) uint64 {
+	return probToThreshold(float64(fraction) / aliasFraction)
+}
@@ -0,0 +1,90 @@
+package walkeralias
+
+import (
+	"math"
+	randv2 "math/rand/v2"
+	"testing"
+)
+
+func TestWalkerAlias_BinaryRoundTrip(t *testing.T) {
+	pMap := map[int]float64{
+		0: 3.5,
+		1: 6.5,
+		2: 10,
+	}
+	w := NewWalkerAlias(pMap, 1)
+
+	data, err := w.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() returned unexpected error: %v", err)
+	}
+
+	loaded, err := NewWalkerAliasFromTable(data, randv2.New(randv2.NewPCG(1, 1)))
+	if err != nil {
+		t.Fatalf("NewWalkerAliasFromTable() returned unexpected error: %v", err)
+	}
+
+	if len(loaded.buckets) != len(w.buckets) {
+		t.Fatalf("loaded %d buckets, want %d", len(loaded.buckets), len(w.buckets))
+	}
+	for key, weight := range pMap {
+		if loaded.weights[key] != weight {
+			t.Errorf("loaded weight for key %d = %v, want %v", key, loaded.weights[key], weight)
+		}
+	}
+
+	// Weights must have survived the round trip well enough for PickN and
+	// Update/Add/Remove (which key off w.weights) to keep working.
+	if got := loaded.PickN(2); len(got) != 2 {
+		t.Errorf("PickN(2) on a loaded table returned %d keys, want 2", len(got))
+	}
+	loaded.Update(0, 100)
+	if loaded.weights[0] != 100 {
+		t.Errorf("Update after load left weight %v, want 100", loaded.weights[0])
+	}
+}
+
+func TestWalkerAlias_UnmarshalBinary_Truncated(t *testing.T) {
+	w := NewWalkerAlias(map[int]float64{0: 1}, 1)
+	data, err := w.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() returned unexpected error: %v", err)
+	}
+
+	// data's last 8 bytes are the only weight's float64; cutting 1-7 bytes
+	// off the end leaves a short, but non-empty, final field. A bare
+	// bytes.Reader.Read is allowed to return that partial read with a nil
+	// error, so this regresses the bug where such input was silently
+	// accepted instead of reported as ErrTruncatedTable.
+	for cut := 1; cut < 8; cut++ {
+		truncated := data[:len(data)-cut]
+		var loaded walkerAlias
+		if err := loaded.UnmarshalBinary(truncated); err == nil {
+			t.Errorf("UnmarshalBinary(data[:-%d]) returned nil error, want ErrTruncatedTable", cut)
+		}
+	}
+}
+
+func TestWalkerAlias_JSONRoundTrip(t *testing.T) {
+	pMap := map[int]float64{0: 1, 1: 2, 2: 3}
+	w := NewWalkerAlias(pMap, 1)
+
+	data, err := w.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON() returned unexpected error: %v", err)
+	}
+
+	var loaded walkerAlias
+	if err := loaded.UnmarshalJSON(data); err != nil {
+		t.Fatalf("UnmarshalJSON() returned unexpected error: %v", err)
+	}
+
+	if len(loaded.buckets) != len(w.buckets) {
+		t.Fatalf("loaded %d buckets, want %d", len(loaded.buckets), len(w.buckets))
+	}
+	for key, weight := range pMap {
+		if math.Abs(loaded.weights[key]-weight) > 1e-9 {
+			t.Errorf("loaded weight for key %d = %v, want %v", key, loaded.weights[key], weight)
+		}
+	}
+}
@@ -1,13 +1,27 @@
 package walkeralias
 
 import (
-	"math/rand"
+	"math"
+	"math/rand/v2"
 )
 
 // walkerAlias holds an internal rand reference instead of sharing with global rand.
 type walkerAlias struct {
 	buckets []*bucket
 	r       *rand.Rand
+
+	// weights tracks the current weight of every key, kept up to date by
+	// Update/Add/Remove so that ensureMutable (see walker-alias-mutable.go)
+	// can bootstrap a Fenwick tree without needing to reverse-engineer the
+	// alias table.
+	weights map[int]float64
+
+	// fenwick, keys and keyPos back the mutable sampling path; they stay nil
+	// until the first Update, Add or Remove call, and are cleared again by
+	// Freeze.
+	fenwick *fenwickTree
+	keys    []int
+	keyPos  map[int]int
 }
 
 // NewWalkerAlias accepts a map {key: probability} and a seed to init a new rand for its own use.
@@ -16,8 +30,21 @@ type walkerAlias struct {
 // WalkerAlias involves an O(n) preprocessing step to generate a probability table.
 // Subsequent sampling are all O(1).
 func NewWalkerAlias(probabilityMap map[int]float64, seed int64) *walkerAlias {
+	return NewWalkerAliasWithRand(probabilityMap, rand.New(rand.NewPCG(0, uint64(seed))))
+}
+
+// NewWalkerAliasWithRand is identical to NewWalkerAlias, but takes a caller-supplied
+// *rand.Rand instead of a seed, so the underlying Source is entirely up to the
+// caller - e.g. a Source drawn from a sync.Pool in concurrent workloads, or
+// the crypto/rand-backed one used by NewWalkerAliasCrypto.
+func NewWalkerAliasWithRand(probabilityMap map[int]float64, r *rand.Rand) *walkerAlias {
 	n := len(probabilityMap)
-	buckets := make([]*bucket, 0)
+	type tempBucket struct {
+		threshold float64
+		key1      int
+		key2      int
+	}
+	tempBuckets := make([]*tempBucket, 0, n)
 
 	var sumWeights float64
 	for _, w := range probabilityMap {
@@ -25,12 +52,12 @@ func NewWalkerAlias(probabilityMap map[int]float64, seed int64) *walkerAlias {
 	}
 	for k, w := range probabilityMap {
 		prob := w * float64(n) / sumWeights
-		buckets = append(buckets, newBucket(k, prob))
+		tempBuckets = append(tempBuckets, &tempBucket{threshold: prob, key1: k, key2: -1})
 	}
 
 	underfull := make([]int, 0)
 	overfull := make([]int, 0)
-	for i, b := range buckets {
+	for i, b := range tempBuckets {
 		if b.threshold < 1 {
 			underfull = append(underfull, i)
 		} else if b.threshold > 1 {
@@ -41,7 +68,7 @@ func NewWalkerAlias(probabilityMap map[int]float64, seed int64) *walkerAlias {
 	for len(underfull) > 0 && len(overfull) > 0 {
 		u, o := underfull[len(underfull)-1], overfull[len(overfull)-1]
 		underfull = underfull[:len(underfull)-1]
-		under, over := buckets[u], buckets[o]
+		under, over := tempBuckets[u], tempBuckets[o]
 		under.key2 = over.key1
 		over.threshold -= 1 - under.threshold
 		if over.threshold < 1 {
@@ -50,31 +77,64 @@ func NewWalkerAlias(probabilityMap map[int]float64, seed int64) *walkerAlias {
 		}
 	}
 
-	return &walkerAlias{buckets: buckets, r: rand.New(rand.NewSource(seed))}
+	buckets := make([]*bucket, 0, n)
+	for _, tb := range tempBuckets {
+		buckets = append(buckets, newBucket(tb.key1, tb.key2, tb.threshold))
+	}
+
+	weights := make(map[int]float64, n)
+	for k, w := range probabilityMap {
+		weights[k] = w
+	}
+
+	return &walkerAlias{buckets: buckets, r: r, weights: weights}
 }
 
-// Random returns a random key following the given probability
+// Random returns a random key following the given probability.
+// Sampling is entirely integer-based: a bucket is picked uniformly via w.r,
+// and the coin flip within that bucket compares a random uint64 against a
+// precomputed uint64 threshold, so no float rounding is introduced at
+// sample time. If w is currently in mutable mode (see Update, Add, Remove),
+// sampling instead goes through the O(log n) Fenwick-tree path until Freeze
+// rebuilds the alias table.
 func (w *walkerAlias) Random() int {
-	bucketIdx := rand.Intn(len(w.buckets))
+	if w.fenwick != nil {
+		return w.randomFenwick()
+	}
+
+	bucketIdx := w.r.Uint64N(uint64(len(w.buckets)))
 	b := w.buckets[bucketIdx]
-	prob := rand.Float64()
-	if prob > b.threshold {
+	if w.r.Uint64() > b.threshold {
 		return b.key2
 	}
 
 	return b.key1
 }
 
-// newBucket returns a ref to a bucket object with the given key
-// and sets its initial threshold to the prob (probability) given
-func newBucket(key int, prob float64) *bucket {
-	return &bucket{threshold: prob, key1: key, key2: -1}
+// newBucket returns a ref to a bucket object with the given keys, converting
+// the [0,1] probability threshold into its uint64 equivalent.
+func newBucket(key1, key2 int, prob float64) *bucket {
+	return &bucket{threshold: probToThreshold(prob), key1: key1, key2: key2}
+}
+
+// probToThreshold converts a probability in [0,1] into its uint64-of-
+// math.MaxUint64 threshold representation. prob >= 1 is clamped directly to
+// math.MaxUint64 rather than routed through the multiply below: float64
+// can't represent math.MaxUint64 exactly (it rounds up to 2^64), so
+// uint64(1 * float64(math.MaxUint64)) overflows back down to half of the
+// uint64 range instead of saturating.
+func probToThreshold(prob float64) uint64 {
+	if prob >= 1 {
+		return math.MaxUint64
+	}
+
+	return uint64(prob * float64(math.MaxUint64))
 }
 
 // bucket holds 2 keys at most,
 // Returns Key1 below or equal to the threshold, Key2 strictly above the threshold
 type bucket struct {
-	threshold float64 // threshold point
-	key1      int     // key below or equal to threshold
-	key2      int     // key above threshold
+	threshold uint64 // threshold point, as a fraction of math.MaxUint64
+	key1      int    // key below or equal to threshold
+	key2      int    // key above threshold
 }
@@ -3,8 +3,10 @@ package walkeralias
 import (
 	"math"
 	"math/rand"
+	randv2 "math/rand/v2"
 	"os"
 	"sort"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -33,6 +35,27 @@ func TestWalkerAlias_Random(t *testing.T) {
 			allowedThreshold: 0.0005, // 0.05%
 			skipInCI: false,
 		},
+		{
+			// Uniform weights give every bucket a threshold of exactly 1.0,
+			// regressing a bug where converting prob==1 to its uint64
+			// representation overflowed instead of saturating, silently
+			// turning about half of the draws for each bucket's key1 into
+			// the key2 sentinel (-1).
+			name: "[WalkerAlias] uniform weights produce an exact threshold of 1.0",
+			pMap: map[int]float64{
+				0: 1,
+				1: 1,
+				2: 1,
+				3: 1,
+			},
+			setupFunc: func(pMap map[int]float64) randomizer {
+				w := NewWalkerAlias(pMap, time.Now().Unix())
+				return w
+			},
+			iterations:       10000000,
+			allowedThreshold: 0.0005, // 0.05%
+			skipInCI: false,
+		},
 		{
 			name: "[WalkerAlias] with floating point rounding errors",
 			pMap: map[int]float64{
@@ -145,6 +168,35 @@ func TestWalkerAlias_Random(t *testing.T) {
 	}
 }
 
+func TestNewWalkerAliasCrypto_Random(t *testing.T) {
+	pMap := map[int]float64{
+		0: 3.5,
+		1: 6.5,
+		2: 10,
+	}
+
+	w := NewWalkerAliasCrypto(pMap)
+	const iterations = 1000000
+	actualPMap := make(map[int]float64)
+	for i := 0; i < iterations; i++ {
+		actualPMap[w.Random()] += float64(1) / float64(iterations)
+	}
+
+	var sumWeights float64
+	for _, v := range pMap {
+		sumWeights += v
+	}
+
+	const allowedThreshold = 0.003
+	for key, weight := range pMap {
+		expectedProb := weight / sumWeights
+		if math.Abs(actualPMap[key]-expectedProb) > allowedThreshold {
+			t.Errorf("actual probability (%.5f%%) differed from expected prob (%.5f%%) by more than acceptable range (%.5f%%)",
+				actualPMap[key], expectedProb, allowedThreshold)
+		}
+	}
+}
+
 type randomizer interface {
 	Random() int
 }
@@ -192,6 +244,30 @@ func BenchmarkWalkerAlias_Random(b *testing.B) {
 	}
 }
 
+// BenchmarkParallel demonstrates that ConcurrentWalkerAlias scales across
+// goroutines - BenchmarkWalkerAlias_Random above hides this bottleneck
+// entirely because it only ever runs on a single goroutine sharing one
+// *rand.Rand.
+func BenchmarkParallel(b *testing.B) {
+	pMap := make(map[int]float64)
+	for i := 1; i <= 10000000; i++ { // 10 million entries
+		pMap[i] = float64(i)
+	}
+
+	w := NewWalkerAlias(pMap, time.Now().Unix())
+	var seedCounter uint64
+	c := NewConcurrentWalkerAlias(w, func() *randv2.Rand {
+		seed := atomic.AddUint64(&seedCounter, 1)
+		return randv2.New(randv2.NewPCG(seed, seed))
+	})
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			c.Random()
+		}
+	})
+}
+
 /*
 	Alternatives to Walker Alias for benchmarking purposes
 */